@@ -1,28 +1,31 @@
 package main
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/cobra"
+	"github.com/user/llm-generator-go/internal/cache"
+	"github.com/user/llm-generator-go/internal/config"
 	"github.com/user/llm-generator-go/internal/content"
 	"github.com/user/llm-generator-go/internal/embedding"
 	"github.com/user/llm-generator-go/internal/formatter"
+	"github.com/user/llm-generator-go/internal/index"
 	"github.com/user/llm-generator-go/internal/repo"
+	"github.com/user/llm-generator-go/internal/state"
 )
 
 func main() {
 	// Load environment variables from .env file
 	godotenv.Load()
 
-	// Check if OpenAI API key is set
-	if os.Getenv("OPENAI_API_KEY") == "" {
-		fmt.Println("Error: OPENAI_API_KEY environment variable is not set")
-		fmt.Println("Please set your OpenAI API key in .env file or as an environment variable")
-		os.Exit(1)
-	}
-
 	var rootCmd = &cobra.Command{
 		Use:   "llm-generator",
 		Short: "Generate LLM-friendly documentation from GitHub repositories",
@@ -33,24 +36,146 @@ func main() {
 		Use:   "generate",
 		Short: "Generate documentation",
 		Run: func(cmd *cobra.Command, args []string) {
-			repoURL, _ := cmd.Flags().GetString("repo")
-			output, _ := cmd.Flags().GetString("output")
-			includeDirs, _ := cmd.Flags().GetStringSlice("include-dirs")
-			excludeDirs, _ := cmd.Flags().GetStringSlice("exclude-dirs")
-			fileTypes, _ := cmd.Flags().GetStringSlice("file-types")
+			configPath, _ := cmd.Flags().GetString("config")
+			jobs, _ := cmd.Flags().GetInt("jobs")
+			cacheDir, _ := cmd.Flags().GetString("cache-dir")
+			noCache, _ := cmd.Flags().GetBool("no-cache")
+			embedder, _ := cmd.Flags().GetString("embedder")
+
+			var cfg *config.Config
+			if configPath != "" {
+				loaded, err := config.Load(configPath)
+				if err != nil {
+					fmt.Printf("Error loading config: %v\n", err)
+					os.Exit(1)
+				}
+				cfg = loaded
+			} else {
+				repoURL, _ := cmd.Flags().GetString("repo")
+				output, _ := cmd.Flags().GetString("output")
+				includeDirs, _ := cmd.Flags().GetStringSlice("include-dirs")
+				excludeDirs, _ := cmd.Flags().GetStringSlice("exclude-dirs")
+				fileTypes, _ := cmd.Flags().GetStringSlice("file-types")
+				formats, _ := cmd.Flags().GetStringSlice("format")
+				sshKey, _ := cmd.Flags().GetString("ssh-key")
+				tokenEnv, _ := cmd.Flags().GetString("token-env")
+
+				if repoURL == "" {
+					fmt.Println("Error: either --repo or --config must be set")
+					os.Exit(1)
+				}
+
+				var auth *config.AuthConfig
+				if sshKey != "" || tokenEnv != "" {
+					auth = &config.AuthConfig{SSHKeyPath: sshKey, TokenEnv: tokenEnv}
+				}
+
+				cfg = &config.Config{
+					Repos: []config.RepoConfig{
+						{
+							URL:         repoURL,
+							Output:      output,
+							IncludeDirs: includeDirs,
+							ExcludeDirs: excludeDirs,
+							FileTypes:   fileTypes,
+							Formats:     formats,
+							Auth:        auth,
+						},
+					},
+				}
+			}
 
-			generateDocs(repoURL, output, includeDirs, excludeDirs, fileTypes)
+			if !generateAll(cfg, jobs, cacheDir, noCache, embedder) {
+				os.Exit(1)
+			}
 		},
 	}
 
-	generateCmd.Flags().String("repo", "", "GitHub repository URL (required)")
+	generateCmd.Flags().String("repo", "", "GitHub repository URL")
 	generateCmd.Flags().String("output", "llm.txt", "Output file path (default: llm.txt)")
 	generateCmd.Flags().StringSlice("include-dirs", []string{}, "Specific directories to include (default: all)")
 	generateCmd.Flags().StringSlice("exclude-dirs", []string{"node_modules", ".git", "__pycache__", "venv", ".venv"}, "Directories to exclude")
 	generateCmd.Flags().StringSlice("file-types", []string{".md", ".go", ".js", ".jsx", ".ts", ".tsx", ".html", ".css", ".json", ".yaml", ".yml"}, "File types to process")
-	generateCmd.MarkFlagRequired("repo")
+	generateCmd.Flags().StringSlice("format", []string{"md"}, "Output formats to produce: md, jsonl, mdx, bundle")
+	generateCmd.Flags().String("config", "", "Path to a TOML config describing one or more repos to generate docs for")
+	generateCmd.Flags().Int("jobs", 1, "Number of repos to process concurrently when using --config")
+	generateCmd.Flags().String("cache-dir", "", "Directory for the on-disk embedding cache (default: XDG_CACHE_HOME or OS cache dir)")
+	generateCmd.Flags().Bool("no-cache", false, "Disable the embedding cache and always call the embeddings API")
+	generateCmd.Flags().String("embedder", "openai", "Embedding backend to use: openai, azure, ollama, or none")
+	generateCmd.Flags().String("ssh-key", "", "Path to an SSH private key for cloning git@ URLs (used with --repo; ignored with --config)")
+	generateCmd.Flags().String("token-env", "", "Name of an environment variable holding a token for HTTPS auth (used with --repo; ignored with --config)")
+
+	var queryCmd = &cobra.Command{
+		Use:   "query",
+		Short: "Query an embedding index produced by generate",
+		Run: func(cmd *cobra.Command, args []string) {
+			indexPath, _ := cmd.Flags().GetString("index")
+			query, _ := cmd.Flags().GetString("q")
+			topK, _ := cmd.Flags().GetInt("top-k")
+			format, _ := cmd.Flags().GetString("format")
+			cacheDir, _ := cmd.Flags().GetString("cache-dir")
+			noCache, _ := cmd.Flags().GetBool("no-cache")
+			embedder, _ := cmd.Flags().GetString("embedder")
+
+			runQuery(indexPath, query, topK, format, cacheDir, noCache, embedder)
+		},
+	}
+
+	queryCmd.Flags().String("index", "llm.index.json", "Path to the embedding index")
+	queryCmd.Flags().String("q", "", "Query to search the index for (required)")
+	queryCmd.Flags().Int("top-k", 5, "Number of results to return")
+	queryCmd.Flags().String("format", "md", "Output format: md or json")
+	queryCmd.Flags().String("cache-dir", "", "Directory for the on-disk embedding cache (default: XDG_CACHE_HOME or OS cache dir)")
+	queryCmd.Flags().Bool("no-cache", false, "Disable the embedding cache and always call the embeddings API")
+	queryCmd.Flags().String("embedder", "openai", "Embedding backend to use: openai, azure, ollama, or none")
+	queryCmd.MarkFlagRequired("q")
+
+	var cacheCmd = &cobra.Command{
+		Use:   "cache",
+		Short: "Inspect and maintain the on-disk embedding cache",
+	}
+
+	var cachePruneCmd = &cobra.Command{
+		Use:   "prune",
+		Short: "Remove cached embeddings older than --max-age",
+		Run: func(cmd *cobra.Command, args []string) {
+			cacheDir, _ := cmd.Flags().GetString("cache-dir")
+			maxAgeStr, _ := cmd.Flags().GetString("max-age")
+
+			maxAge, err := cache.ParseMaxAge(maxAgeStr)
+			if err != nil {
+				fmt.Printf("Error parsing --max-age: %v\n", err)
+				os.Exit(1)
+			}
+
+			if cacheDir == "" {
+				cacheDir = cache.DefaultDir()
+			}
+
+			c, err := cache.New(cacheDir, 0)
+			if err != nil {
+				fmt.Printf("Error opening cache at %s: %v\n", cacheDir, err)
+				os.Exit(1)
+			}
+
+			removed, err := c.Prune(maxAge)
+			if err != nil {
+				fmt.Printf("Error pruning cache: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Printf("Removed %d cached embedding(s) older than %s from %s\n", removed, maxAgeStr, cacheDir)
+		},
+	}
+
+	cachePruneCmd.Flags().String("max-age", "30d", "Remove cache entries older than this (e.g. 30d, 12h)")
+	cachePruneCmd.Flags().String("cache-dir", "", "Directory of the on-disk embedding cache (default: XDG_CACHE_HOME or OS cache dir)")
+
+	cacheCmd.AddCommand(cachePruneCmd)
 
 	rootCmd.AddCommand(generateCmd)
+	rootCmd.AddCommand(queryCmd)
+	rootCmd.AddCommand(cacheCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println(err)
@@ -58,28 +183,96 @@ func main() {
 	}
 }
 
-func generateDocs(repoURL, outputPath string, includeDirs, excludeDirs, fileTypes []string) {
+// generateAll runs generateOne for every repo in cfg, processing up to
+// jobs repos concurrently. It returns false if any repo failed, so callers
+// such as CI can exit non-zero instead of reporting false success.
+func generateAll(cfg *config.Config, jobs int, cacheDir string, noCache bool, embedderKind string) bool {
+	repos := cfg.ResolvedRepos()
+
+	if jobs < 1 {
+		jobs = 1
+	}
+
+	sem := make(chan struct{}, jobs)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	ok := true
+
+	for _, repoCfg := range repos {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(repoCfg config.RepoConfig) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := generateOne(repoCfg, cacheDir, noCache, embedderKind); err != nil {
+				mu.Lock()
+				ok = false
+				mu.Unlock()
+			}
+		}(repoCfg)
+	}
+
+	wg.Wait()
+	return ok
+}
+
+// generateOne clones a single repo, processes its files, embeds the
+// resulting code blocks, and writes out the documentation and index. If a
+// state manifest from a prior run is found, files that did not change since
+// that run's commit reuse their embeddings from the prior index instead of
+// being re-embedded. Every file is still re-processed; rendered sections
+// are not reused from the prior run.
+func generateOne(repoCfg config.RepoConfig, cacheDir string, noCache bool, embedderKind string) error {
+	repoURL := repoCfg.URL
+	outputPath := repoCfg.Output
+	if outputPath == "" {
+		outputPath = "llm.txt"
+	}
+	indexPath := indexPathFor(outputPath)
+	statePath := statePathFor(outputPath)
+
 	fmt.Println("Generating documentation from repository:", repoURL)
 
-	// Clone repository
+	prevState, err := state.Load(statePath)
+	hasPrevState := err == nil && prevState.RepoURL == repoURL
+
+	// Clone repository. A prior run means we need full history so we can
+	// diff against the commit it left off at.
 	fmt.Println("Cloning repository...")
 	repoHandler := repo.NewRepoHandler()
-	repoPath, err := repoHandler.CloneRepo(repoURL)
+	auth := repo.AuthOptions{}
+	if repoCfg.Auth != nil {
+		auth.SSHKeyPath = repoCfg.Auth.SSHKeyPath
+		auth.TokenEnv = repoCfg.Auth.TokenEnv
+	}
+	repoPath, err := repoHandler.CloneRepo(repoURL, hasPrevState, auth, repoCfg.Branch, repoCfg.Ref)
 	if err != nil {
-		fmt.Printf("Error cloning repository: %v\n", err)
-		os.Exit(1)
+		fmt.Printf("Error cloning repository %s: %v\n", repoURL, err)
+		return err
 	}
 
 	// Extract repository name for output
 	repoName := repoHandler.GetRepoName(repoURL)
 
+	var changedBlobs map[string]string
+	var prevEntriesBySource map[string][]index.Entry
+	if hasPrevState {
+		changedBlobs, err = repoHandler.ChangedFiles(prevState.CommitSHA)
+		if err != nil {
+			fmt.Printf("Warning: failed to diff against prior commit for %s, doing a full regeneration: %v\n", repoURL, err)
+			hasPrevState = false
+		} else if prevIndex, err := index.Load(indexPath); err == nil {
+			prevEntriesBySource = groupBySource(prevIndex.Entries)
+		}
+	}
+
 	// Process content
 	fmt.Println("Processing files...")
-	processor := content.NewContentProcessor(repoPath, includeDirs, excludeDirs, fileTypes)
+	processor := content.NewContentProcessor(repoPath, repoCfg.IncludeDirs, repoCfg.ExcludeDirs, repoCfg.FileTypes)
 	files, err := processor.GetFiles()
 	if err != nil {
-		fmt.Printf("Error getting files: %v\n", err)
-		os.Exit(1)
+		fmt.Printf("Error getting files for %s: %v\n", repoURL, err)
+		return err
 	}
 
 	fmt.Printf("Found %d files to process\n", len(files))
@@ -90,31 +283,214 @@ func generateDocs(repoURL, outputPath string, includeDirs, excludeDirs, fileType
 			fmt.Printf("Warning: Failed to process file %s: %v\n", file, err)
 			continue
 		}
+
+		relPath, err := filepath.Rel(repoPath, file)
+		if err != nil {
+			fmt.Printf("Warning: Failed to resolve relative path for %s: %v\n", file, err)
+			continue
+		}
+
+		if commitInfo, err := repoHandler.LastCommitForFile(relPath); err == nil {
+			processedContent.LastCommit = &content.LastCommit{
+				SHA:    commitInfo.SHA,
+				Author: commitInfo.Author,
+				Date:   commitInfo.Date,
+			}
+		}
+
+		if hasPrevState {
+			if _, changed := changedBlobs[relPath]; !changed {
+				if prevEntries, ok := prevEntriesBySource[relPath]; ok && len(prevEntries) == len(processedContent.CodeBlocks) {
+					for i := range processedContent.CodeBlocks {
+						processedContent.CodeBlocks[i].Embedding = prevEntries[i].Embedding
+					}
+				}
+			}
+		}
+
 		contents = append(contents, processedContent)
 		fmt.Printf("Processed file: %s\n", file)
 	}
 
-	// Create embeddings
+	// Create embeddings. CreateEmbeddings skips any block that already has
+	// an embedding, so only new or changed files incur an API call.
 	fmt.Println("Creating embeddings...")
-	embeddingService := embedding.NewEmbeddingService()
+	embeddingService, err := embedding.NewEmbeddingService(embedderKind, cacheDir, noCache)
+	if err != nil {
+		fmt.Printf("Error initializing embedding service for %s: %v\n", repoURL, err)
+		return err
+	}
 	indexedContents, err := embeddingService.CreateEmbeddings(contents)
 	if err != nil {
-		fmt.Printf("Error creating embeddings: %v\n", err)
-		os.Exit(1)
+		fmt.Printf("Error creating embeddings for %s: %v\n", repoURL, err)
+		return err
 	}
 
-	// Format documentation
+	// Persist the embeddings so they can be searched later instead of
+	// being thrown away once the markdown is written.
+	fmt.Println("Saving embedding index...")
+	embeddingIndex := index.New()
+	embeddingIndex.Add(indexedContents)
+	if err := embeddingIndex.Save(indexPath); err != nil {
+		fmt.Printf("Error saving embedding index for %s: %v\n", repoURL, err)
+		return err
+	}
+
+	// Format documentation. A repo can request more than one output
+	// format in a single run.
 	fmt.Println("Formatting documentation...")
-	docFormatter := formatter.NewDocFormatter()
-	documentation := docFormatter.FormatDocs(indexedContents, repoURL, repoName)
+	formats := repoCfg.Formats
+	if len(formats) == 0 {
+		formats = []string{"md"}
+	}
+
+	formatters, err := resolveFormatters(formats)
+	if err != nil {
+		fmt.Printf("Error resolving formats for %s: %v\n", repoURL, err)
+		return err
+	}
+
+	meta := formatter.Meta{
+		RepoURL:    repoURL,
+		RepoName:   repoName,
+		OutputPath: outputPath,
+		OutputBase: strings.TrimSuffix(outputPath, filepath.Ext(outputPath)),
+	}
+
+	for _, f := range formatters {
+		artifacts, err := f.Format(indexedContents, meta)
+		if err != nil {
+			fmt.Printf("Error formatting %s: %v\n", repoURL, err)
+			return err
+		}
 
-	// Write to output file
-	err = os.WriteFile(outputPath, []byte(documentation), 0644)
+		for _, artifact := range artifacts {
+			if dir := filepath.Dir(artifact.Path); dir != "." {
+				if err := os.MkdirAll(dir, 0755); err != nil {
+					fmt.Printf("Error creating output directory %s: %v\n", dir, err)
+					return err
+				}
+			}
+			if err := os.WriteFile(artifact.Path, artifact.Data, 0644); err != nil {
+				fmt.Printf("Error writing %s: %v\n", artifact.Path, err)
+				return err
+			}
+			fmt.Printf("Wrote artifact: %s\n", artifact.Path)
+		}
+	}
+
+	// Record what this run saw so the next run can diff against it.
+	headCommit, err := repoHandler.HeadCommit()
 	if err != nil {
-		fmt.Printf("Error writing output file: %v\n", err)
+		fmt.Printf("Warning: failed to resolve HEAD commit for %s, incremental state not saved: %v\n", repoURL, err)
+	} else {
+		newState := state.New(repoURL, headCommit)
+		for _, c := range indexedContents {
+			hash := sha256.Sum256([]byte(c.Content))
+			blobSHA := changedBlobs[c.Source]
+			if blobSHA == "" && hasPrevState {
+				blobSHA = prevState.PerFile[c.Source].BlobSHA
+			}
+			newState.PerFile[c.Source] = state.FileState{
+				BlobSHA:     blobSHA,
+				ContentHash: hex.EncodeToString(hash[:]),
+			}
+		}
+		if err := newState.Save(statePath); err != nil {
+			fmt.Printf("Warning: failed to save incremental state for %s: %v\n", repoURL, err)
+		}
+	}
+
+	fmt.Println("done: " + repoURL)
+	for _, name := range formats {
+		if name == "md" {
+			fmt.Printf("Output file: %s\n", outputPath)
+			break
+		}
+	}
+	fmt.Printf("Index file: %s\n", indexPath)
+
+	return nil
+}
+
+// indexPathFor derives the embedding index path for a given output file,
+// e.g. "docs/llm.txt" -> "docs/llm.index.json".
+func indexPathFor(outputPath string) string {
+	ext := filepath.Ext(outputPath)
+	base := strings.TrimSuffix(outputPath, ext)
+	return base + ".index.json"
+}
+
+// statePathFor derives the incremental state manifest path for a given
+// output file, placed alongside it as a dotfile.
+func statePathFor(outputPath string) string {
+	return filepath.Join(filepath.Dir(outputPath), ".llm-gen-state.json")
+}
+
+// resolveFormatters maps output format names to Formatter instances.
+func resolveFormatters(names []string) ([]formatter.Formatter, error) {
+	formatters := make([]formatter.Formatter, 0, len(names))
+	for _, name := range names {
+		switch name {
+		case "md":
+			formatters = append(formatters, formatter.NewMarkdownFormatter())
+		case "jsonl":
+			formatters = append(formatters, formatter.NewJSONLFormatter())
+		case "mdx":
+			formatters = append(formatters, formatter.NewMDXFormatter())
+		case "bundle":
+			formatters = append(formatters, formatter.NewBundleFormatter())
+		default:
+			return nil, fmt.Errorf("unknown output format %q", name)
+		}
+	}
+	return formatters, nil
+}
+
+// groupBySource buckets index entries by their source file, preserving
+// the order they were added in.
+func groupBySource(entries []index.Entry) map[string][]index.Entry {
+	grouped := make(map[string][]index.Entry)
+	for _, entry := range entries {
+		grouped[entry.Source] = append(grouped[entry.Source], entry)
+	}
+	return grouped
+}
+
+// runQuery embeds a search query and prints the most similar code blocks
+// from a previously generated embedding index.
+func runQuery(indexPath, query string, topK int, format string, cacheDir string, noCache bool, embedderKind string) {
+	embeddingIndex, err := index.Load(indexPath)
+	if err != nil {
+		fmt.Printf("Error loading index: %v\n", err)
 		os.Exit(1)
 	}
 
-	fmt.Println("\n‚úÖ Documentation generated successfully!")
-	fmt.Printf("üìù Output file: %s\n", outputPath)
+	embeddingService, err := embedding.NewEmbeddingService(embedderKind, cacheDir, noCache)
+	if err != nil {
+		fmt.Printf("Error initializing embedding service: %v\n", err)
+		os.Exit(1)
+	}
+	queryEmbedding, err := embeddingService.Embed(query)
+	if err != nil {
+		fmt.Printf("Error embedding query: %v\n", err)
+		os.Exit(1)
+	}
+
+	results := embeddingIndex.Search(queryEmbedding, topK)
+
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			fmt.Printf("Error formatting results: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(data))
+	default:
+		for i, result := range results {
+			fmt.Printf("## %d. %s (score: %.4f)\n\n", i+1, result.Entry.Source, result.Score)
+			fmt.Printf("```%s\n%s\n```\n\n", result.Entry.Language, result.Entry.Code)
+		}
+	}
 }
@@ -0,0 +1,239 @@
+// Package cache provides a content-hash-keyed, two-tier (memory + disk)
+// cache for embedding vectors, so repeated runs over the same code don't
+// pay for the same OpenAI call twice.
+package cache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultMaxMemoryBytes bounds the in-process LRU tier to roughly 256MB.
+const DefaultMaxMemoryBytes = 256 * 1024 * 1024
+
+// Cache is a two-tier cache for embedding vectors, keyed by a hash of the
+// model and the text that was embedded.
+type Cache struct {
+	dir    string
+	noDisk bool
+
+	mu        sync.Mutex
+	maxBytes  int64
+	usedBytes int64
+	order     *list.List
+	entries   map[string]*list.Element
+}
+
+type lruEntry struct {
+	key   string
+	value []float64
+}
+
+// New creates a Cache backed by dir on disk (created if needed) with an
+// in-process LRU capped at maxMemoryBytes. A zero maxMemoryBytes uses
+// DefaultMaxMemoryBytes. Pass an empty dir to disable the disk tier.
+func New(dir string, maxMemoryBytes int64) (*Cache, error) {
+	if maxMemoryBytes <= 0 {
+		maxMemoryBytes = DefaultMaxMemoryBytes
+	}
+
+	c := &Cache{
+		dir:      dir,
+		maxBytes: maxMemoryBytes,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+
+	if dir == "" {
+		c.noDisk = true
+	} else if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create cache dir: %w", err)
+	}
+
+	return c, nil
+}
+
+// Key derives the cache key for a given model and input text.
+func Key(model, text string) string {
+	sum := sha256.Sum256([]byte(model + "\n" + text))
+	return hex.EncodeToString(sum[:])
+}
+
+// GetOrCompute returns the cached embedding for key, computing it with
+// compute and caching the result on a miss.
+func (c *Cache) GetOrCompute(key string, compute func() ([]float64, error)) ([]float64, error) {
+	if v, ok := c.getMemory(key); ok {
+		return v, nil
+	}
+
+	if v, ok := c.getDisk(key); ok {
+		c.putMemory(key, v)
+		return v, nil
+	}
+
+	v, err := compute()
+	if err != nil {
+		return nil, err
+	}
+
+	c.putMemory(key, v)
+	c.putDisk(key, v)
+
+	return v, nil
+}
+
+func (c *Cache) getMemory(key string) ([]float64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*lruEntry).value, true
+}
+
+func (c *Cache) putMemory(key string, value []float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	size := int64(len(value) * 8)
+
+	if el, ok := c.entries[key]; ok {
+		c.usedBytes -= int64(len(el.Value.(*lruEntry).value) * 8)
+		el.Value = &lruEntry{key: key, value: value}
+		c.order.MoveToFront(el)
+		c.usedBytes += size
+	} else {
+		el := c.order.PushFront(&lruEntry{key: key, value: value})
+		c.entries[key] = el
+		c.usedBytes += size
+	}
+
+	for c.usedBytes > c.maxBytes {
+		oldest := c.order.Back()
+		if oldest == nil {
+			break
+		}
+		entry := oldest.Value.(*lruEntry)
+		c.usedBytes -= int64(len(entry.value) * 8)
+		c.order.Remove(oldest)
+		delete(c.entries, entry.key)
+	}
+}
+
+// diskPath shards entries into two-character prefix directories so a
+// single directory never holds too many files.
+func (c *Cache) diskPath(key string) string {
+	return filepath.Join(c.dir, key[:2], key+".vec")
+}
+
+func (c *Cache) getDisk(key string) ([]float64, bool) {
+	if c.noDisk {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(c.diskPath(key))
+	if err != nil {
+		return nil, false
+	}
+
+	return decodeVector(data), true
+}
+
+func (c *Cache) putDisk(key string, value []float64) {
+	if c.noDisk {
+		return
+	}
+
+	path := c.diskPath(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+
+	_ = os.WriteFile(path, encodeVector(value), 0644)
+}
+
+// Prune removes disk-cached entries older than maxAge, returning how many
+// were removed.
+func (c *Cache) Prune(maxAge time.Duration) (int, error) {
+	if c.noDisk {
+		return 0, nil
+	}
+
+	cutoff := time.Now().Add(-maxAge)
+	removed := 0
+
+	err := filepath.Walk(c.dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(path) != ".vec" {
+			return nil
+		}
+		if info.ModTime().Before(cutoff) {
+			if err := os.Remove(path); err != nil {
+				return err
+			}
+			removed++
+		}
+		return nil
+	})
+
+	return removed, err
+}
+
+// DefaultDir returns the default on-disk cache directory, honoring
+// XDG_CACHE_HOME when set.
+func DefaultDir() string {
+	if xdg := os.Getenv("XDG_CACHE_HOME"); xdg != "" {
+		return filepath.Join(xdg, "llm-generator", "embeddings")
+	}
+
+	base, err := os.UserCacheDir()
+	if err != nil {
+		base = os.TempDir()
+	}
+
+	return filepath.Join(base, "llm-generator", "embeddings")
+}
+
+func encodeVector(v []float64) []byte {
+	buf := make([]byte, len(v)*8)
+	for i, f := range v {
+		binary.LittleEndian.PutUint64(buf[i*8:], math.Float64bits(f))
+	}
+	return buf
+}
+
+func decodeVector(data []byte) []float64 {
+	v := make([]float64, len(data)/8)
+	for i := range v {
+		v[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[i*8:]))
+	}
+	return v
+}
+
+// ParseMaxAge parses a duration like "30d", in addition to everything
+// time.ParseDuration already accepts.
+func ParseMaxAge(s string) (time.Duration, error) {
+	if strings.HasSuffix(s, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(s, "d"))
+		if err != nil {
+			return 0, fmt.Errorf("invalid max-age %q: %w", s, err)
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(s)
+}
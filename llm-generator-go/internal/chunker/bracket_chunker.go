@@ -0,0 +1,82 @@
+package chunker
+
+import (
+	"regexp"
+	"strings"
+)
+
+var bracketDeclRegex = regexp.MustCompile(`^\s*(?:export\s+)?(?:default\s+)?(?:async\s+)?(?:function\*?|class)\s+([A-Za-z_$][A-Za-z0-9_$]*)`)
+
+// splitBracketLanguage breaks JS/TS-family source into one chunk per
+// top-level function/class declaration, using brace-balancing rather than
+// a full parser to find each declaration's end.
+func splitBracketLanguage(code string) []Chunk {
+	lines := strings.Split(code, "\n")
+
+	type match struct {
+		line   int
+		symbol string
+	}
+
+	// Track brace depth across the whole file so only top-level
+	// declarations start a new chunk; a nested named function/class (a
+	// helper closure, a factory-nested class, etc.) is covered by its
+	// enclosing declaration's line range instead of truncating it.
+	var matches []match
+	depth := 0
+	for i, line := range lines {
+		if m := bracketDeclRegex.FindStringSubmatch(line); m != nil && depth == 0 {
+			matches = append(matches, match{line: i, symbol: m[1]})
+		}
+		for _, r := range line {
+			switch r {
+			case '{':
+				depth++
+			case '}':
+				if depth > 0 {
+					depth--
+				}
+			}
+		}
+	}
+
+	if len(matches) == 0 {
+		return []Chunk{{Code: code}}
+	}
+
+	chunks := make([]Chunk, 0, len(matches))
+	for _, m := range matches {
+		end := bracketBalancedEnd(lines, m.line)
+
+		chunks = append(chunks, Chunk{
+			Symbol:    m.symbol,
+			Code:      strings.Join(lines[m.line:end], "\n"),
+			StartLine: m.line + 1,
+			EndLine:   end,
+		})
+	}
+
+	return chunks
+}
+
+// bracketBalancedEnd scans forward from startLine until every opened
+// brace has been closed, returning the exclusive end line.
+func bracketBalancedEnd(lines []string, startLine int) int {
+	depth := 0
+	seenOpen := false
+	for i := startLine; i < len(lines); i++ {
+		for _, r := range lines[i] {
+			switch r {
+			case '{':
+				depth++
+				seenOpen = true
+			case '}':
+				depth--
+			}
+		}
+		if seenOpen && depth <= 0 {
+			return i + 1
+		}
+	}
+	return len(lines)
+}
@@ -0,0 +1,101 @@
+// Package chunker splits source code into symbol-sized pieces so that
+// embeddings are computed per function/class/type instead of per whole
+// file, which keeps them small enough to be useful for retrieval.
+package chunker
+
+import "strings"
+
+// approxCharsPerToken approximates the embedding model's tokenizer so we
+// can enforce a token budget without actually tokenizing.
+const approxCharsPerToken = 4
+
+// maxTokensPerChunk keeps chunks comfortably under the embedding model's
+// 8191 token limit.
+const maxTokensPerChunk = 2000
+
+const maxCharsPerChunk = maxTokensPerChunk * approxCharsPerToken
+
+// Chunk is a single symbol-sized slice of a source file.
+type Chunk struct {
+	Symbol    string
+	Code      string
+	StartLine int
+	EndLine   int
+}
+
+// Split breaks code into chunks appropriate for language. Unrecognized
+// languages fall back to a single whole-file chunk.
+func Split(code, language string) []Chunk {
+	var chunks []Chunk
+
+	switch language {
+	case "go":
+		chunks = splitGo(code)
+	case "python":
+		chunks = splitPython(code)
+	case "javascript", "typescript", "jsx", "tsx":
+		chunks = splitBracketLanguage(code)
+	default:
+		chunks = []Chunk{{Code: code}}
+	}
+
+	return splitOversizeChunks(chunks)
+}
+
+// splitOversizeChunks further breaks any chunk over the token budget on
+// blank lines. A piece is cut at the first blank line after it reaches the
+// budget, so pieces can run past the budget up to that blank line, and a
+// stretch with no blank line is not split at all.
+func splitOversizeChunks(chunks []Chunk) []Chunk {
+	result := make([]Chunk, 0, len(chunks))
+	for _, c := range chunks {
+		if len(c.Code) <= maxCharsPerChunk {
+			result = append(result, c)
+			continue
+		}
+		result = append(result, splitOnBlankLines(c)...)
+	}
+	return result
+}
+
+func splitOnBlankLines(chunk Chunk) []Chunk {
+	lines := strings.Split(chunk.Code, "\n")
+
+	var result []Chunk
+	var current []string
+	currentLen := 0
+	lineOffset := 0
+
+	flush := func(throughLine int) {
+		if len(current) == 0 {
+			return
+		}
+		result = append(result, Chunk{
+			Symbol:    chunk.Symbol,
+			Code:      strings.Join(current, "\n"),
+			StartLine: chunk.StartLine + lineOffset,
+			EndLine:   chunk.StartLine + throughLine - 1,
+		})
+		current = nil
+		currentLen = 0
+	}
+
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" && currentLen >= maxCharsPerChunk {
+			flush(i)
+			// The blank line itself is about to be appended to the next
+			// chunk's current below, so the next chunk's StartLine is
+			// this line, not the one after it.
+			lineOffset = i
+		}
+		current = append(current, line)
+		currentLen += len(line) + 1
+	}
+	flush(len(lines))
+
+	if len(result) == 0 {
+		return []Chunk{chunk}
+	}
+
+	return result
+}
@@ -0,0 +1,185 @@
+package chunker
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitGo(t *testing.T) {
+	code := `package demo
+
+// Add returns the sum of a and b.
+func Add(a, b int) int {
+	return a + b
+}
+
+type Point struct {
+	X, Y int
+}
+`
+	chunks := Split(code, "go")
+	if len(chunks) != 2 {
+		t.Fatalf("got %d chunks, want 2: %+v", len(chunks), chunks)
+	}
+	if chunks[0].Symbol != "Add" {
+		t.Errorf("chunks[0].Symbol = %q, want %q", chunks[0].Symbol, "Add")
+	}
+	if !strings.Contains(chunks[0].Code, "// Add returns the sum") {
+		t.Errorf("chunks[0].Code missing leading doc comment: %q", chunks[0].Code)
+	}
+	if chunks[1].Symbol != "Point" {
+		t.Errorf("chunks[1].Symbol = %q, want %q", chunks[1].Symbol, "Point")
+	}
+}
+
+func TestSplitGoInvalidSourceFallsBackToWholeFile(t *testing.T) {
+	code := "this is not valid go source {{{"
+	chunks := Split(code, "go")
+	if len(chunks) != 1 || chunks[0].Code != code {
+		t.Fatalf("got %+v, want single whole-file chunk", chunks)
+	}
+}
+
+func TestSplitPythonTopLevelOnly(t *testing.T) {
+	code := "def outer():\n    def inner():\n        pass\n    return 5\n\nclass Foo:\n    def method(self):\n        pass\n"
+	chunks := Split(code, "python")
+
+	var symbols []string
+	for _, c := range chunks {
+		symbols = append(symbols, c.Symbol)
+	}
+	want := []string{"outer", "Foo"}
+	if len(symbols) != len(want) {
+		t.Fatalf("got symbols %v, want %v", symbols, want)
+	}
+	for i := range want {
+		if symbols[i] != want[i] {
+			t.Errorf("symbols[%d] = %q, want %q", i, symbols[i], want[i])
+		}
+	}
+
+	// The nested "inner" def must not produce its own chunk, and must be
+	// covered by outer's line range instead.
+	for _, c := range chunks {
+		if c.Symbol == "inner" {
+			t.Fatalf("nested def produced its own chunk: %+v", c)
+		}
+	}
+	if !strings.Contains(chunks[0].Code, "def inner():") {
+		t.Errorf("outer chunk should contain nested inner def, got: %q", chunks[0].Code)
+	}
+}
+
+func TestSplitBracketLanguage(t *testing.T) {
+	code := `export function greet(name) {
+  if (name) {
+    return "hi " + name;
+  }
+  return "hi";
+}
+
+class Greeter {
+  greet() {
+    return "hi";
+  }
+}
+`
+	chunks := Split(code, "javascript")
+	if len(chunks) != 2 {
+		t.Fatalf("got %d chunks, want 2: %+v", len(chunks), chunks)
+	}
+	if chunks[0].Symbol != "greet" {
+		t.Errorf("chunks[0].Symbol = %q, want %q", chunks[0].Symbol, "greet")
+	}
+	if chunks[1].Symbol != "Greeter" {
+		t.Errorf("chunks[1].Symbol = %q, want %q", chunks[1].Symbol, "Greeter")
+	}
+}
+
+func TestSplitBracketLanguageNestedFunctionTopLevelOnly(t *testing.T) {
+	code := `export function useWidget() {
+  function helper(x) {
+    return x + 1;
+  }
+  return helper(1);
+}
+
+class Factory {
+  build() {
+    return 1;
+  }
+}
+`
+	chunks := Split(code, "typescript")
+
+	var symbols []string
+	for _, c := range chunks {
+		symbols = append(symbols, c.Symbol)
+	}
+	want := []string{"useWidget", "Factory"}
+	if len(symbols) != len(want) {
+		t.Fatalf("got symbols %v, want %v", symbols, want)
+	}
+	for i := range want {
+		if symbols[i] != want[i] {
+			t.Errorf("symbols[%d] = %q, want %q", i, symbols[i], want[i])
+		}
+	}
+
+	if !strings.Contains(chunks[0].Code, "function helper(x)") {
+		t.Errorf("useWidget chunk should contain nested helper, got: %q", chunks[0].Code)
+	}
+	if !strings.Contains(chunks[0].Code, "return helper(1);") {
+		t.Errorf("useWidget chunk should retain its body after the nested function, got: %q", chunks[0].Code)
+	}
+}
+
+func TestSplitUnknownLanguageFallsBackToWholeFile(t *testing.T) {
+	code := "whatever content"
+	chunks := Split(code, "ruby")
+	if len(chunks) != 1 || chunks[0].Code != code {
+		t.Fatalf("got %+v, want single whole-file chunk", chunks)
+	}
+}
+
+// TestSplitOnBlankLinesStartLine guards against the StartLine off-by-one:
+// once an oversize chunk is split on a blank line, the chunk starting after
+// the split must report the blank line itself as its StartLine, since the
+// blank line is what gets carried into its Code.
+func TestSplitOnBlankLinesStartLine(t *testing.T) {
+	line := strings.Repeat("x", 600)
+	var lines []string
+	for i := 0; i < 20; i++ {
+		lines = append(lines, line)
+	}
+	// Insert a blank line after enough content has accumulated to cross
+	// maxCharsPerChunk, forcing a split right there.
+	blankAt := 20
+	lines = append(lines[:blankAt], append([]string{""}, lines[blankAt:]...)...)
+	lines = append(lines, line, line)
+
+	chunk := Chunk{StartLine: 1, Code: strings.Join(lines, "\n")}
+	result := splitOnBlankLines(chunk)
+	if len(result) < 2 {
+		t.Fatalf("got %d chunks, want at least 2 to exercise the split: %+v", len(result), result)
+	}
+
+	second := result[1]
+	secondLines := strings.Split(second.Code, "\n")
+	if secondLines[0] != "" {
+		t.Fatalf("second chunk's first line should be the blank line that triggered the split, got %q", secondLines[0])
+	}
+
+	wantStart := chunk.StartLine + blankAt
+	if second.StartLine != wantStart {
+		t.Errorf("second chunk StartLine = %d, want %d", second.StartLine, wantStart)
+	}
+}
+
+func TestSplitOnBlankLinesSmallChunkUntouched(t *testing.T) {
+	chunk := Chunk{Symbol: "f", StartLine: 10, Code: "a\nb\nc"}
+	result := splitOnBlankLines(chunk)
+	if len(result) != 1 || result[0].Code != chunk.Code {
+		t.Fatalf("got %+v, want chunk returned unchanged", result)
+	}
+}
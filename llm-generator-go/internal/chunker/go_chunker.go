@@ -0,0 +1,90 @@
+package chunker
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+)
+
+// splitGo parses a Go source file and emits one chunk per top-level
+// declaration (func, method, type, or var/const group), including its
+// leading doc comment. Files that fail to parse fall back to a single
+// whole-file chunk.
+func splitGo(code string) []Chunk {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", code, parser.ParseComments)
+	if err != nil {
+		return []Chunk{{Code: code}}
+	}
+
+	lines := strings.Split(code, "\n")
+
+	var chunks []Chunk
+	for _, decl := range file.Decls {
+		startLine := fset.Position(decl.Pos()).Line
+		if doc := declDoc(decl); doc != nil {
+			startLine = fset.Position(doc.Pos()).Line
+		}
+		endLine := fset.Position(decl.End()).Line
+
+		chunks = append(chunks, Chunk{
+			Symbol:    declSymbol(decl),
+			Code:      strings.Join(lines[startLine-1:endLine], "\n"),
+			StartLine: startLine,
+			EndLine:   endLine,
+		})
+	}
+
+	if len(chunks) == 0 {
+		return []Chunk{{Code: code}}
+	}
+
+	return chunks
+}
+
+func declDoc(decl ast.Decl) *ast.CommentGroup {
+	switch d := decl.(type) {
+	case *ast.GenDecl:
+		return d.Doc
+	case *ast.FuncDecl:
+		return d.Doc
+	}
+	return nil
+}
+
+func declSymbol(decl ast.Decl) string {
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		if d.Recv != nil && len(d.Recv.List) > 0 {
+			if recv := receiverName(d.Recv.List[0].Type); recv != "" {
+				return recv + "." + d.Name.Name
+			}
+		}
+		return d.Name.Name
+	case *ast.GenDecl:
+		var names []string
+		for _, spec := range d.Specs {
+			switch s := spec.(type) {
+			case *ast.TypeSpec:
+				names = append(names, s.Name.Name)
+			case *ast.ValueSpec:
+				for _, n := range s.Names {
+					names = append(names, n.Name)
+				}
+			}
+		}
+		return strings.Join(names, ", ")
+	}
+	return ""
+}
+
+func receiverName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return receiverName(t.X)
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
@@ -0,0 +1,62 @@
+package chunker
+
+import (
+	"regexp"
+	"strings"
+)
+
+var pythonDefRegex = regexp.MustCompile(`^(\s*)(?:def|class)\s+([A-Za-z_][A-Za-z0-9_]*)`)
+
+// splitPython breaks Python source into one chunk per top-level def/class,
+// tracking indentation to find where each one ends.
+func splitPython(code string) []Chunk {
+	lines := strings.Split(code, "\n")
+
+	type match struct {
+		line   int
+		indent int
+		symbol string
+	}
+
+	var matches []match
+	for i, line := range lines {
+		m := pythonDefRegex.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		// Only top-level defs/classes start a new chunk; nested ones are
+		// covered by their enclosing chunk's line range.
+		if len(m[1]) > 0 {
+			continue
+		}
+		matches = append(matches, match{line: i, indent: len(m[1]), symbol: m[2]})
+	}
+
+	if len(matches) == 0 {
+		return []Chunk{{Code: code}}
+	}
+
+	chunks := make([]Chunk, 0, len(matches))
+	for _, m := range matches {
+		end := len(lines)
+		for j := m.line + 1; j < len(lines); j++ {
+			if strings.TrimSpace(lines[j]) == "" {
+				continue
+			}
+			indent := len(lines[j]) - len(strings.TrimLeft(lines[j], " \t"))
+			if indent <= m.indent {
+				end = j
+				break
+			}
+		}
+
+		chunks = append(chunks, Chunk{
+			Symbol:    m.symbol,
+			Code:      strings.Join(lines[m.line:end], "\n"),
+			StartLine: m.line + 1,
+			EndLine:   end,
+		})
+	}
+
+	return chunks
+}
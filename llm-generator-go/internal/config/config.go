@@ -0,0 +1,84 @@
+// Package config loads the TOML configuration used to drive batch
+// documentation generation across multiple repositories.
+package config
+
+import (
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+)
+
+// AuthConfig describes how to authenticate when cloning a private
+// repository. At most one of the two fields should be set.
+type AuthConfig struct {
+	TokenEnv   string `toml:"token_env"`
+	SSHKeyPath string `toml:"ssh_key_path"`
+}
+
+// RepoConfig describes a single repository to generate documentation for.
+type RepoConfig struct {
+	URL         string      `toml:"url"`
+	Output      string      `toml:"output"`
+	IncludeDirs []string    `toml:"include_dirs"`
+	ExcludeDirs []string    `toml:"exclude_dirs"`
+	FileTypes   []string    `toml:"file_types"`
+	Formats     []string    `toml:"formats"`
+	Ref         string      `toml:"ref"`
+	Branch      string      `toml:"branch"`
+	Auth        *AuthConfig `toml:"auth"`
+}
+
+// Defaults holds values inherited by every RepoConfig that doesn't
+// override them.
+type Defaults struct {
+	Output      string   `toml:"output"`
+	IncludeDirs []string `toml:"include_dirs"`
+	ExcludeDirs []string `toml:"exclude_dirs"`
+	FileTypes   []string `toml:"file_types"`
+	Formats     []string `toml:"formats"`
+}
+
+// Config is the top-level shape of an llm-gen.toml file.
+type Config struct {
+	Defaults Defaults     `toml:"defaults"`
+	Repos    []RepoConfig `toml:"repos"`
+}
+
+// Load reads and parses a TOML configuration file.
+func Load(path string) (*Config, error) {
+	var cfg Config
+	if _, err := toml.DecodeFile(path, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	if len(cfg.Repos) == 0 {
+		return nil, fmt.Errorf("config file %s declares no repos", path)
+	}
+
+	return &cfg, nil
+}
+
+// ResolvedRepos returns the configured repos with any unset fields
+// filled in from Defaults.
+func (c *Config) ResolvedRepos() []RepoConfig {
+	resolved := make([]RepoConfig, len(c.Repos))
+	for i, r := range c.Repos {
+		if r.Output == "" {
+			r.Output = c.Defaults.Output
+		}
+		if len(r.IncludeDirs) == 0 {
+			r.IncludeDirs = c.Defaults.IncludeDirs
+		}
+		if len(r.ExcludeDirs) == 0 {
+			r.ExcludeDirs = c.Defaults.ExcludeDirs
+		}
+		if len(r.FileTypes) == 0 {
+			r.FileTypes = c.Defaults.FileTypes
+		}
+		if len(r.Formats) == 0 {
+			r.Formats = c.Defaults.Formats
+		}
+		resolved[i] = r
+	}
+	return resolved
+}
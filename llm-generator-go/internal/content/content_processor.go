@@ -7,6 +7,9 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
+
+	"github.com/user/llm-generator-go/internal/chunker"
 )
 
 // ContentProcessor processes content from files in a repository
@@ -25,12 +28,28 @@ type Content struct {
 	Content     string      `json:"content"`
 	CodeBlocks  []CodeBlock `json:"code_blocks"`
 	Type        string      `json:"type"`
+	LastCommit  *LastCommit `json:"last_commit,omitempty"`
+}
+
+// LastCommit describes the most recent commit that touched a file's
+// source, for display alongside the generated documentation.
+type LastCommit struct {
+	SHA    string    `json:"sha"`
+	Author string    `json:"author"`
+	Date   time.Time `json:"date"`
 }
 
-// CodeBlock represents a code block in a file
+// CodeBlock represents a code block in a file. For Go, Python, and
+// JS/TS-family code files this is a single symbol (function, method,
+// type, class, ...) rather than the whole file; Symbol, StartLine, and
+// EndLine describe where it came from. Other code files keep the whole
+// file as one block.
 type CodeBlock struct {
 	Language  string    `json:"language"`
 	Code      string    `json:"code"`
+	Symbol    string    `json:"symbol,omitempty"`
+	StartLine int       `json:"start_line,omitempty"`
+	EndLine   int       `json:"end_line,omitempty"`
 	Embedding []float64 `json:"embedding,omitempty"`
 }
 
@@ -272,17 +291,27 @@ func (p *ContentProcessor) processCode(content, fileExt, filePath string) Conten
 	// Extract filename as title
 	title := filepath.Base(filePath)
 
+	// Split into symbol-sized chunks (one per function/method/type/class)
+	// rather than embedding the whole file as a single block. Languages
+	// the chunker does not recognize come back as one whole-file chunk.
+	chunks := chunker.Split(content, language)
+	codeBlocks := make([]CodeBlock, 0, len(chunks))
+	for _, c := range chunks {
+		codeBlocks = append(codeBlocks, CodeBlock{
+			Language:  language,
+			Code:      c.Code,
+			Symbol:    c.Symbol,
+			StartLine: c.StartLine,
+			EndLine:   c.EndLine,
+		})
+	}
+
 	return Content{
 		Title:       title,
 		Description: description,
 		Source:      filePath,
 		Content:     content,
-		CodeBlocks: []CodeBlock{
-			{
-				Language: language,
-				Code:     content,
-			},
-		},
-		Type: "code",
+		CodeBlocks:  codeBlocks,
+		Type:        "code",
 	}
 }
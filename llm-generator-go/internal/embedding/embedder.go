@@ -0,0 +1,168 @@
+package embedding
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// Embedder embeds a batch of text inputs into vectors, one per input and
+// in the same order. Implementations wrap a specific embeddings backend.
+type Embedder interface {
+	Embed(ctx context.Context, texts []string) ([][]float64, error)
+}
+
+// NewEmbedder constructs the Embedder named by kind ("openai", "azure",
+// "ollama", or "none"; "" defaults to "openai"), reading any
+// provider-specific configuration from environment variables. It also
+// returns a short identifier for the selected backend and model, used as
+// part of the embedding cache key so different backends never collide.
+func NewEmbedder(kind string) (Embedder, string, error) {
+	switch kind {
+	case "", "openai":
+		apiKey := os.Getenv("OPENAI_API_KEY")
+		if apiKey == "" {
+			return nil, "", fmt.Errorf("OPENAI_API_KEY must be set to use the openai embedder")
+		}
+		client := openai.NewClient(apiKey)
+		model := openai.AdaEmbeddingV2
+		return &openAIEmbedder{client: client, model: model}, "openai:" + string(model), nil
+
+	case "azure":
+		apiKey := os.Getenv("AZURE_OPENAI_API_KEY")
+		baseURL := os.Getenv("AZURE_OPENAI_BASE_URL")
+		deployment := os.Getenv("AZURE_OPENAI_DEPLOYMENT")
+		if apiKey == "" || baseURL == "" || deployment == "" {
+			return nil, "", fmt.Errorf("AZURE_OPENAI_API_KEY, AZURE_OPENAI_BASE_URL, and AZURE_OPENAI_DEPLOYMENT must all be set to use the azure embedder")
+		}
+		cfg := openai.DefaultAzureConfig(apiKey, baseURL)
+		cfg.AzureModelMapperFunc = func(model string) string {
+			return deployment
+		}
+		client := openai.NewClientWithConfig(cfg)
+		return &openAIEmbedder{client: client, model: openai.AdaEmbeddingV2}, "azure:" + deployment, nil
+
+	case "ollama":
+		baseURL := os.Getenv("OLLAMA_BASE_URL")
+		if baseURL == "" {
+			baseURL = "http://localhost:11434"
+		}
+		model := os.Getenv("OLLAMA_MODEL")
+		if model == "" {
+			model = "nomic-embed-text"
+		}
+		return &ollamaEmbedder{baseURL: baseURL, model: model, httpClient: &http.Client{}}, "ollama:" + model, nil
+
+	case "none":
+		return &noopEmbedder{}, "none", nil
+
+	default:
+		return nil, "", fmt.Errorf("unknown embedder %q (want openai, azure, ollama, or none)", kind)
+	}
+}
+
+// openAIEmbedder embeds text via the OpenAI embeddings API. It is also
+// used for Azure OpenAI, which speaks the same request/response shape
+// once the client is configured with an Azure base URL and deployment.
+type openAIEmbedder struct {
+	client *openai.Client
+	model  openai.EmbeddingModel
+}
+
+func (e *openAIEmbedder) Embed(ctx context.Context, texts []string) ([][]float64, error) {
+	resp, err := e.client.CreateEmbeddings(ctx, openai.EmbeddingRequest{
+		Input: texts,
+		Model: e.model,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create embedding: %w", err)
+	}
+
+	if len(resp.Data) != len(texts) {
+		return nil, fmt.Errorf("embedding response contained %d vectors for %d inputs", len(resp.Data), len(texts))
+	}
+
+	vectors := make([][]float64, len(resp.Data))
+	for i, d := range resp.Data {
+		vectors[i] = float32sToFloat64s(d.Embedding)
+	}
+
+	return vectors, nil
+}
+
+// ollamaEmbedder embeds text via a local Ollama server's /api/embeddings
+// endpoint. Ollama embeds one prompt per request, so Embed issues one
+// HTTP call per input text.
+type ollamaEmbedder struct {
+	baseURL    string
+	model      string
+	httpClient *http.Client
+}
+
+type ollamaEmbeddingRequest struct {
+	Model  string `json:"model"`
+	Prompt string `json:"prompt"`
+}
+
+type ollamaEmbeddingResponse struct {
+	Embedding []float64 `json:"embedding"`
+}
+
+func (e *ollamaEmbedder) Embed(ctx context.Context, texts []string) ([][]float64, error) {
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		body, err := json.Marshal(ollamaEmbeddingRequest{Model: e.model, Prompt: text})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode ollama embedding request: %w", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.baseURL+"/api/embeddings", bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to build ollama embedding request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := e.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to call ollama embeddings endpoint: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("ollama embeddings endpoint returned status %d", resp.StatusCode)
+		}
+
+		var parsed ollamaEmbeddingResponse
+		err = json.NewDecoder(resp.Body).Decode(&parsed)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode ollama embedding response: %w", err)
+		}
+
+		vectors[i] = parsed.Embedding
+	}
+
+	return vectors, nil
+}
+
+// noopEmbedder skips embedding entirely, so documentation can still be
+// generated without any embeddings API key configured. Semantic search
+// over the resulting index will not be meaningful.
+type noopEmbedder struct{}
+
+func (e *noopEmbedder) Embed(ctx context.Context, texts []string) ([][]float64, error) {
+	return make([][]float64, len(texts)), nil
+}
+
+func float32sToFloat64s(in []float32) []float64 {
+	out := make([]float64, len(in))
+	for i, v := range in {
+		out[i] = float64(v)
+	}
+	return out
+}
@@ -3,26 +3,60 @@ package embedding
 import (
 	"context"
 	"fmt"
-	"os"
 
-	"github.com/sashabaranov/go-openai"
+	"github.com/user/llm-generator-go/internal/cache"
 	"github.com/user/llm-generator-go/internal/content"
 )
 
 // EmbeddingService is responsible for creating embeddings for content
 type EmbeddingService struct {
-	client *openai.Client
-	model  openai.EmbeddingModel
+	embedder Embedder
+	modelKey string
+	cache    *cache.Cache
 }
 
-// NewEmbeddingService creates a new embedding service
-func NewEmbeddingService() *EmbeddingService {
-	apiKey := os.Getenv("OPENAI_API_KEY")
-	client := openai.NewClient(apiKey)
-	return &EmbeddingService{
-		client: client,
-		model:  openai.AdaEmbeddingV2,
+// NewEmbeddingService creates a new embedding service backed by the
+// embedder named by embedderKind ("openai", "azure", "ollama", or
+// "none"; "" defaults to "openai"). If cacheDir is empty, cache.DefaultDir
+// is used; pass noCache to disable caching entirely.
+func NewEmbeddingService(embedderKind, cacheDir string, noCache bool) (*EmbeddingService, error) {
+	embedder, modelKey, err := NewEmbedder(embedderKind)
+	if err != nil {
+		return nil, err
 	}
+
+	svc := &EmbeddingService{
+		embedder: embedder,
+		modelKey: modelKey,
+	}
+
+	if !noCache {
+		dir := cacheDir
+		if dir == "" {
+			dir = cache.DefaultDir()
+		}
+		c, err := cache.New(dir, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize embedding cache: %w", err)
+		}
+		svc.cache = c
+	}
+
+	return svc, nil
+}
+
+// Embed creates a single embedding for an arbitrary string, such as a
+// user-provided search query.
+func (s *EmbeddingService) Embed(text string) ([]float64, error) {
+	compute := func() ([]float64, error) {
+		return s.embedRemote(text)
+	}
+
+	if s.cache == nil {
+		return compute()
+	}
+
+	return s.cache.GetOrCompute(cache.Key(s.modelKey, text), compute)
 }
 
 // CreateEmbeddings creates embeddings for content
@@ -35,35 +69,52 @@ func (s *EmbeddingService) CreateEmbeddings(contents []content.Content) ([]conte
 				continue
 			}
 
+			// Skip blocks that already carry an embedding, e.g. ones
+			// reused from a prior incremental run
+			if len(contents[i].CodeBlocks[j].Embedding) > 0 {
+				continue
+			}
+
 			// Truncate code to fit embedding model limit (8191 tokens)
 			code := contents[i].CodeBlocks[j].Code
 			if len(code) > 32000 {
 				code = code[:32000]
 			}
 
-			// Create embedding
-			resp, err := s.client.CreateEmbeddings(
-				context.Background(),
-				openai.EmbeddingRequest{
-					Input: []string{code},
-					Model: s.model,
-				},
+			compute := func() ([]float64, error) {
+				return s.embedRemote(code)
+			}
+
+			var (
+				vec []float64
+				err error
 			)
+			if s.cache != nil {
+				vec, err = s.cache.GetOrCompute(cache.Key(s.modelKey, code), compute)
+			} else {
+				vec, err = compute()
+			}
 			if err != nil {
-				return nil, fmt.Errorf("failed to create embedding: %w", err)
+				return nil, err
 			}
 
-			if len(resp.Data) > 0 {
-				// Convert []float32 to []float64
-				float32Embedding := resp.Data[0].Embedding
-				float64Embedding := make([]float64, len(float32Embedding))
-				for k, v := range float32Embedding {
-					float64Embedding[k] = float64(v)
-				}
-				contents[i].CodeBlocks[j].Embedding = float64Embedding
-			}
+			contents[i].CodeBlocks[j].Embedding = vec
 		}
 	}
 
 	return contents, nil
 }
+
+// embedRemote calls the configured embedder for a single input.
+func (s *EmbeddingService) embedRemote(text string) ([]float64, error) {
+	vectors, err := s.embedder.Embed(context.Background(), []string{text})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(vectors) == 0 {
+		return nil, fmt.Errorf("embedder returned no vectors")
+	}
+
+	return vectors[0], nil
+}
@@ -0,0 +1,67 @@
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/user/llm-generator-go/internal/content"
+	"github.com/user/llm-generator-go/internal/util"
+)
+
+// BundleFormatter emits one file per processed source plus a
+// manifest.json describing them, in the shape expected by OpenAI's
+// file-search and Anthropic's document upload APIs.
+type BundleFormatter struct{}
+
+// NewBundleFormatter creates a new bundle formatter.
+func NewBundleFormatter() *BundleFormatter {
+	return &BundleFormatter{}
+}
+
+// bundleManifestEntry describes a single file within the bundle.
+type bundleManifestEntry struct {
+	ID       string `json:"id"`
+	Filename string `json:"filename"`
+	Source   string `json:"source"`
+	Title    string `json:"title"`
+}
+
+// bundleManifest is the top-level shape of a bundle's manifest.json.
+type bundleManifest struct {
+	RepoURL  string                `json:"repo_url"`
+	RepoName string                `json:"repo_name"`
+	Files    []bundleManifestEntry `json:"files"`
+}
+
+// Format implements Formatter.
+func (f *BundleFormatter) Format(contents []content.Content, meta Meta) ([]Artifact, error) {
+	dir := meta.OutputBase + ".bundle"
+	artifacts := make([]Artifact, 0, len(contents)+1)
+	manifest := bundleManifest{RepoURL: meta.RepoURL, RepoName: meta.RepoName}
+
+	for i, c := range contents {
+		slug := util.Slugify(c.Source)
+		filename := fmt.Sprintf("%03d-%s.txt", i+1, slug)
+
+		artifacts = append(artifacts, Artifact{
+			Path: fmt.Sprintf("%s/%s", dir, filename),
+			Data: []byte(c.Content),
+		})
+
+		manifest.Files = append(manifest.Files, bundleManifestEntry{
+			ID:       slug,
+			Filename: filename,
+			Source:   c.Source,
+			Title:    c.Title,
+		})
+	}
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal bundle manifest: %w", err)
+	}
+
+	artifacts = append(artifacts, Artifact{Path: dir + "/manifest.json", Data: manifestData})
+
+	return artifacts, nil
+}
@@ -0,0 +1,26 @@
+// Package formatter renders processed repository content into one or
+// more output artifacts.
+package formatter
+
+import "github.com/user/llm-generator-go/internal/content"
+
+// Meta carries repository context a Formatter needs to render output.
+type Meta struct {
+	RepoURL    string
+	RepoName   string
+	OutputPath string // the --output path as given, e.g. "docs/llm.txt"
+	OutputBase string // OutputPath with its extension stripped, e.g. "docs/llm"
+}
+
+// Artifact is a single file a Formatter wants written to disk, with Path
+// relative to the working directory.
+type Artifact struct {
+	Path string
+	Data []byte
+}
+
+// Formatter renders processed repository content into one or more output
+// artifacts.
+type Formatter interface {
+	Format(contents []content.Content, meta Meta) ([]Artifact, error)
+}
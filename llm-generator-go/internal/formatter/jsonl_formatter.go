@@ -0,0 +1,33 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/user/llm-generator-go/internal/content"
+)
+
+// JSONLFormatter renders one JSON object per processed file, one per
+// line — suitable for fine-tuning or embedding pipelines.
+type JSONLFormatter struct{}
+
+// NewJSONLFormatter creates a new JSONL formatter.
+func NewJSONLFormatter() *JSONLFormatter {
+	return &JSONLFormatter{}
+}
+
+// Format implements Formatter.
+func (f *JSONLFormatter) Format(contents []content.Content, meta Meta) ([]Artifact, error) {
+	var buf bytes.Buffer
+	for _, c := range contents {
+		data, err := json.Marshal(c)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal content for %s: %w", c.Source, err)
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+
+	return []Artifact{{Path: meta.OutputBase + ".jsonl", Data: buf.Bytes()}}, nil
+}
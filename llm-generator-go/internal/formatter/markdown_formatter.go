@@ -0,0 +1,71 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/user/llm-generator-go/internal/content"
+	"github.com/user/llm-generator-go/internal/util"
+)
+
+// MarkdownFormatter renders documentation as a single markdown file —
+// the tool's original output format.
+type MarkdownFormatter struct{}
+
+// NewMarkdownFormatter creates a new markdown formatter.
+func NewMarkdownFormatter() *MarkdownFormatter {
+	return &MarkdownFormatter{}
+}
+
+// Format implements Formatter.
+func (f *MarkdownFormatter) Format(contents []content.Content, meta Meta) ([]Artifact, error) {
+	var doc strings.Builder
+
+	// Add header
+	doc.WriteString(fmt.Sprintf("# %s Documentation\n\n", meta.RepoName))
+	doc.WriteString(fmt.Sprintf("Repository: %s\n", meta.RepoURL))
+	doc.WriteString(fmt.Sprintf("Generated: %s\n\n", time.Now().Format("2006-01-02 15:04:05")))
+	doc.WriteString("## Contents\n\n")
+
+	// Add table of contents
+	for i, c := range contents {
+		doc.WriteString(fmt.Sprintf("%d. [%s](#%d-%s)\n", i+1, c.Title, i+1, util.Slugify(c.Title)))
+	}
+
+	doc.WriteString("\n---\n\n")
+
+	// Add content
+	for i, c := range contents {
+		doc.WriteString(fmt.Sprintf("## %d. %s\n\n", i+1, c.Title))
+		doc.WriteString(fmt.Sprintf("**Source:** %s\n\n", c.Source))
+		if c.LastCommit != nil {
+			doc.WriteString(fmt.Sprintf("**Last updated:** %s by %s on %s\n\n",
+				shortSHA(c.LastCommit.SHA), c.LastCommit.Author, c.LastCommit.Date.Format("2006-01-02")))
+		}
+		doc.WriteString(fmt.Sprintf("%s\n\n", c.Description))
+
+		if len(c.CodeBlocks) > 0 {
+			for j, block := range c.CodeBlocks {
+				if block.Symbol != "" {
+					doc.WriteString(fmt.Sprintf("### %s\n\n", block.Symbol))
+				} else {
+					doc.WriteString(fmt.Sprintf("### Code Block %d\n\n", j+1))
+				}
+				doc.WriteString(fmt.Sprintf("```%s\n%s\n```\n\n", block.Language, block.Code))
+			}
+		}
+
+		doc.WriteString("---\n\n")
+	}
+
+	return []Artifact{{Path: meta.OutputPath, Data: []byte(doc.String())}}, nil
+}
+
+// shortSHA truncates a commit SHA to its short form.
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -0,0 +1,46 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/user/llm-generator-go/internal/content"
+	"github.com/user/llm-generator-go/internal/util"
+)
+
+// MDXFormatter renders one MDX file per processed source, with
+// frontmatter metadata and component-friendly code fences.
+type MDXFormatter struct{}
+
+// NewMDXFormatter creates a new MDX formatter.
+func NewMDXFormatter() *MDXFormatter {
+	return &MDXFormatter{}
+}
+
+// Format implements Formatter.
+func (f *MDXFormatter) Format(contents []content.Content, meta Meta) ([]Artifact, error) {
+	dir := meta.OutputBase + ".mdx"
+	artifacts := make([]Artifact, 0, len(contents))
+
+	for _, c := range contents {
+		var doc strings.Builder
+		doc.WriteString("---\n")
+		doc.WriteString(fmt.Sprintf("title: %q\n", c.Title))
+		doc.WriteString(fmt.Sprintf("source: %q\n", c.Source))
+		doc.WriteString(fmt.Sprintf("type: %q\n", c.Type))
+		doc.WriteString("---\n\n")
+		doc.WriteString(c.Description)
+		doc.WriteString("\n\n")
+
+		for _, block := range c.CodeBlocks {
+			doc.WriteString(fmt.Sprintf("```%s\n%s\n```\n\n", block.Language, block.Code))
+		}
+
+		artifacts = append(artifacts, Artifact{
+			Path: fmt.Sprintf("%s/%s.mdx", dir, util.Slugify(c.Source)),
+			Data: []byte(doc.String()),
+		})
+	}
+
+	return artifacts, nil
+}
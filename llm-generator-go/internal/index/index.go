@@ -0,0 +1,130 @@
+// Package index persists code-block embeddings so they can be searched
+// later instead of being discarded after documentation is generated.
+package index
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+
+	"github.com/user/llm-generator-go/internal/content"
+)
+
+// Entry is a single embedded code block stored in the index.
+type Entry struct {
+	Source    string    `json:"source"`
+	Title     string    `json:"title"`
+	Language  string    `json:"language"`
+	Code      string    `json:"code"`
+	Embedding []float64 `json:"embedding"`
+}
+
+// Index is a flat, on-disk collection of embedded code blocks.
+type Index struct {
+	Entries []Entry `json:"entries"`
+}
+
+// Result is a single scored hit returned by Search.
+type Result struct {
+	Entry Entry   `json:"entry"`
+	Score float64 `json:"score"`
+}
+
+// New creates an empty index.
+func New() *Index {
+	return &Index{}
+}
+
+// Add appends every embedded code block found in contents to the index.
+// Code blocks without an embedding (e.g. skipped because they were empty)
+// are ignored.
+func (idx *Index) Add(contents []content.Content) {
+	for _, c := range contents {
+		for _, block := range c.CodeBlocks {
+			if len(block.Embedding) == 0 {
+				continue
+			}
+			idx.Entries = append(idx.Entries, Entry{
+				Source:    c.Source,
+				Title:     c.Title,
+				Language:  block.Language,
+				Code:      block.Code,
+				Embedding: block.Embedding,
+			})
+		}
+	}
+}
+
+// Save writes the index to path as JSON.
+func (idx *Index) Save(path string) error {
+	data, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal index: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write index file: %w", err)
+	}
+
+	return nil
+}
+
+// Load reads an index previously written by Save.
+func Load(path string) (*Index, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index file: %w", err)
+	}
+
+	var idx Index
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return nil, fmt.Errorf("failed to parse index file: %w", err)
+	}
+
+	return &idx, nil
+}
+
+// Search returns the topK entries whose embedding is most similar to
+// queryEmbedding, ranked by cosine similarity (highest first).
+func (idx *Index) Search(queryEmbedding []float64, topK int) []Result {
+	results := make([]Result, 0, len(idx.Entries))
+	for _, entry := range idx.Entries {
+		results = append(results, Result{
+			Entry: entry,
+			Score: cosineSimilarity(queryEmbedding, entry.Embedding),
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+
+	if topK > 0 && topK < len(results) {
+		results = results[:topK]
+	}
+
+	return results
+}
+
+// cosineSimilarity returns the cosine similarity of two vectors. It
+// returns 0 if either vector is empty or they differ in length.
+func cosineSimilarity(a, b []float64) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
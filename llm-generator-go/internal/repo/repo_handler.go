@@ -8,8 +8,29 @@ import (
 	"time"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/go-git/go-git/v5/utils/merkletrie"
 )
 
+// AuthOptions describes how to authenticate when cloning a private
+// repository. At most one of the two fields should be set; SSHKeyPath
+// applies to git@ URLs and TokenEnv applies to https:// URLs.
+type AuthOptions struct {
+	SSHKeyPath string
+	TokenEnv   string
+}
+
+// CommitInfo describes a commit relevant to a file or to the repository
+// as a whole.
+type CommitInfo struct {
+	SHA    string    `json:"sha"`
+	Author string    `json:"author"`
+	Date   time.Time `json:"date"`
+}
+
 // RepoHandler handles repository operations
 type RepoHandler struct {
 	tempDir string
@@ -20,8 +41,15 @@ func NewRepoHandler() *RepoHandler {
 	return &RepoHandler{}
 }
 
-// CloneRepo clones a repository from the given URL
-func (h *RepoHandler) CloneRepo(repoURL string) (string, error) {
+// CloneRepo clones a repository from the given URL. When fullHistory is
+// true the clone keeps its full commit history (needed to diff against a
+// previously seen commit); otherwise it's a shallow, single-commit clone.
+// auth configures authentication for private repositories; its zero value
+// clones https:// URLs anonymously and git@ URLs with ~/.ssh/id_rsa. branch checks out a specific branch; ref
+// checks out a specific tag. At most one of branch and ref should be set;
+// branch takes precedence if both are, and the repository's default
+// branch is used if neither is.
+func (h *RepoHandler) CloneRepo(repoURL string, fullHistory bool, auth AuthOptions, branch, ref string) (string, error) {
 	// Create a temporary directory for the repository
 	tempDir, err := os.MkdirTemp("", "llm-generator-")
 	if err != nil {
@@ -30,16 +58,30 @@ func (h *RepoHandler) CloneRepo(repoURL string) (string, error) {
 	h.tempDir = tempDir
 
 	// Validate repository URL
-	if !strings.HasPrefix(repoURL, "https://github.com/") && !strings.HasPrefix(repoURL, "https://gitlab.com/") {
+	isSSH := strings.HasPrefix(repoURL, "git@github.com:") || strings.HasPrefix(repoURL, "git@gitlab.com:")
+	isHTTPS := strings.HasPrefix(repoURL, "https://github.com/") || strings.HasPrefix(repoURL, "https://gitlab.com/")
+	if !isSSH && !isHTTPS {
 		return "", fmt.Errorf("only GitHub and GitLab repositories are supported")
 	}
 
+	authMethod, err := resolveAuth(isSSH, auth)
+	if err != nil {
+		return "", err
+	}
+
+	depth := 1
+	if fullHistory {
+		depth = 0
+	}
+
 	// Clone the repository
 	_, err = git.PlainClone(tempDir, false, &git.CloneOptions{
-		URL:          repoURL,
-		Progress:     os.Stdout,
-		Depth:        1,
-		SingleBranch: true,
+		URL:           repoURL,
+		Auth:          authMethod,
+		Progress:      os.Stdout,
+		Depth:         depth,
+		SingleBranch:  true,
+		ReferenceName: resolveReferenceName(branch, ref),
 	})
 	if err != nil {
 		return "", fmt.Errorf("failed to clone repository: %w", err)
@@ -48,6 +90,149 @@ func (h *RepoHandler) CloneRepo(repoURL string) (string, error) {
 	return tempDir, nil
 }
 
+// resolveReferenceName builds the reference to check out for a clone.
+// branch resolves to a branch reference and ref to a tag reference; an
+// empty return value leaves go-git to check out the default branch.
+func resolveReferenceName(branch, ref string) plumbing.ReferenceName {
+	if branch != "" {
+		return plumbing.NewBranchReferenceName(branch)
+	}
+	if ref != "" {
+		return plumbing.NewTagReferenceName(ref)
+	}
+	return ""
+}
+
+// resolveAuth builds the go-git auth method for a clone, if any. SSH URLs
+// are authenticated with a private key; HTTPS URLs are authenticated with
+// a personal access token read from the environment variable named by
+// auth.TokenEnv, if set.
+func resolveAuth(isSSH bool, auth AuthOptions) (transport.AuthMethod, error) {
+	if isSSH {
+		keyPath := auth.SSHKeyPath
+		if keyPath == "" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve default SSH key path: %w", err)
+			}
+			keyPath = filepath.Join(home, ".ssh", "id_rsa")
+		}
+
+		keys, err := ssh.NewPublicKeysFromFile("git", keyPath, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to load SSH key %s: %w", keyPath, err)
+		}
+		return keys, nil
+	}
+
+	if auth.TokenEnv == "" {
+		return nil, nil
+	}
+
+	token := os.Getenv(auth.TokenEnv)
+	if token == "" {
+		return nil, fmt.Errorf("environment variable %s is not set", auth.TokenEnv)
+	}
+
+	return &githttp.BasicAuth{
+		Username: "x-token-auth",
+		Password: token,
+	}, nil
+}
+
+// HeadCommit returns the SHA of the currently checked out commit.
+func (h *RepoHandler) HeadCommit() (string, error) {
+	repository, err := git.PlainOpen(h.tempDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	head, err := repository.Head()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+
+	return head.Hash().String(), nil
+}
+
+// ChangedFiles returns the relative paths and new blob SHAs of every file
+// that was added or modified between sinceCommit and the current HEAD.
+// The repository must have been cloned with fullHistory so sinceCommit is
+// still reachable.
+func (h *RepoHandler) ChangedFiles(sinceCommit string) (map[string]string, error) {
+	repository, err := git.PlainOpen(h.tempDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	oldCommit, err := repository.CommitObject(plumbing.NewHash(sinceCommit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve prior commit %s: %w", sinceCommit, err)
+	}
+	oldTree, err := oldCommit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prior tree: %w", err)
+	}
+
+	head, err := repository.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	newCommit, err := repository.CommitObject(head.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve HEAD commit: %w", err)
+	}
+	newTree, err := newCommit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read HEAD tree: %w", err)
+	}
+
+	changes, err := oldTree.Diff(newTree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff commit trees: %w", err)
+	}
+
+	changed := make(map[string]string)
+	for _, change := range changes {
+		action, err := change.Action()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read change action: %w", err)
+		}
+		if action == merkletrie.Delete {
+			continue
+		}
+		changed[change.To.Name] = change.To.TreeEntry.Hash.String()
+	}
+
+	return changed, nil
+}
+
+// LastCommitForFile returns metadata about the most recent commit that
+// touched path (relative to the repository root).
+func (h *RepoHandler) LastCommitForFile(path string) (*CommitInfo, error) {
+	repository, err := git.PlainOpen(h.tempDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	commitIter, err := repository.Log(&git.LogOptions{FileName: &path})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commit log for %s: %w", path, err)
+	}
+	defer commitIter.Close()
+
+	commit, err := commitIter.Next()
+	if err != nil {
+		return nil, fmt.Errorf("no commit history found for %s: %w", path, err)
+	}
+
+	return &CommitInfo{
+		SHA:    commit.Hash.String(),
+		Author: commit.Author.Name,
+		Date:   commit.Author.When,
+	}, nil
+}
+
 // GetRepoName extracts the repository name from the URL
 func (h *RepoHandler) GetRepoName(repoURL string) string {
 	// Remove trailing .git if present
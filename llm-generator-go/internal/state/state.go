@@ -0,0 +1,61 @@
+// Package state persists per-repo run metadata so that a later run can
+// skip re-processing and re-embedding files that haven't changed.
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// FileState records what a prior run observed for a single file.
+type FileState struct {
+	BlobSHA      string `json:"blob_sha"`
+	ContentHash  string `json:"content_hash"`
+	EmbeddingIDs []int  `json:"embedding_ids,omitempty"`
+}
+
+// State is the sidecar manifest written alongside a generated artifact.
+type State struct {
+	RepoURL   string               `json:"repo_url"`
+	CommitSHA string               `json:"commit_sha"`
+	PerFile   map[string]FileState `json:"per_file"`
+}
+
+// New creates an empty State for repoURL at commitSHA.
+func New(repoURL, commitSHA string) *State {
+	return &State{
+		RepoURL:   repoURL,
+		CommitSHA: commitSHA,
+		PerFile:   make(map[string]FileState),
+	}
+}
+
+// Load reads a state manifest previously written by Save.
+func Load(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse state file: %w", err)
+	}
+
+	return &s, nil
+}
+
+// Save writes the state manifest to path as JSON.
+func (s *State) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+
+	return nil
+}
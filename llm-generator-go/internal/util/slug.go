@@ -0,0 +1,18 @@
+// Package util holds small helpers shared across packages.
+package util
+
+import "strings"
+
+// Slugify creates a URL- and filename-friendly slug from a string, used
+// consistently across formatters so generated anchors and filenames line
+// up.
+func Slugify(s string) string {
+	s = strings.ToLower(s)
+	for _, c := range []string{" ", "/", "\\"} {
+		s = strings.ReplaceAll(s, c, "-")
+	}
+	for _, c := range []string{".", ",", ":", ";", "!", "?", "(", ")", "[", "]", "{", "}"} {
+		s = strings.ReplaceAll(s, c, "")
+	}
+	return s
+}